@@ -1,12 +1,12 @@
 // Package slackbot hopes to ease development of Slack bots by adding helpful
-// methods and a mux-router style interface to the github.com/nlopes/slack package.
+// methods and a mux-router style interface to the github.com/slack-go/slack package.
 //
 // Incoming Slack RTM events are mapped to a handler in the following form:
 // 	bot.Hear("(?i)how are you(.*)").MessageHandler(HowAreYouHandler)
 //
 // The package adds Reply and ReplyWithAttachments methods:
 //	func HowAreYouHandler(ctx context.Context, bot *slackbot.Bot, evt *slack.MessageEvent) {
-// 		bot.Reply(evt, "A bit tired. You get it? A bit?", slackbot.WithTyping)
+// 		bot.Reply(ctx, evt, "A bit tired. You get it? A bit?")
 //	}
 //
 //	func HowAreYouAttachmentsHandler(ctx context.Context, bot *slackbot.Bot, evt *slack.MessageEvent) {
@@ -22,10 +22,10 @@
 // 		}
 //
 //		attachments := []slack.Attachment{attachment}
-//		bot.ReplyWithAttachments(evt, attachments, slackbot.WithTyping)
+//		bot.ReplyWithAttachments(ctx, evt, txt, attachments...)
 //	}
 //
-// The slackbot package exposes  github.com/nlopes/slack RTM and Client objects
+// The slackbot package exposes  github.com/slack-go/slack RTM and Client objects
 // enabling a consumer to interact with the lower level package directly:
 // 	func HowAreYouHandler(ctx context.Context, bot *slackbot.Bot, evt *slack.MessageEvent) {
 // 		bot.RTM.NewOutgoingMessage("Hello", "#random")
@@ -40,7 +40,9 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/nlopes/slack"
+	"github.com/kaedys/go-slackbot/interactive"
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/socketmode"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -61,7 +63,21 @@ type Bot struct {
 	RTM                   *slack.RTM
 	TypingDelayMultiplier float64 // Multiplier on typing delay.  Default 0 -> no delay.  1 -> 2ms per character, 5 -> 10ms per, 0.5 -> 1ms per. Max delay is 2000ms regardless.
 
+	socketClient *socketmode.Client // Set by RunSocketMode; nil when running via RTM.
+	sources      []MessageSource    // Registered via AddSource; multiplexed into the router by RunSources.
+
+	interactive   *interactive.Router // Lazily constructed by Interactive().
+	signingSecret string              // Used to verify HTTP-delivered interactive-component requests.
+
 	debugging bool
+	logger    Logger // Set by WithLogger; falls back to logrus's standard logger when nil.
+}
+
+// Logger is the structured logging interface the bot writes its observability output through -
+// *logrus.Logger and *logrus.Entry both satisfy it. Pass one to WithLogger to capture or redirect the
+// logging WithDebugging and RunSources's match/dispatch entries produce.
+type Logger interface {
+	log.FieldLogger
 }
 
 // Returns a copy of the bot with debugging enabled.  Intended to be daisychained with the New() constructor.
@@ -72,6 +88,23 @@ func (b *Bot) WithDebugging() *Bot {
 	return &newB
 }
 
+// WithLogger returns a copy of the bot that writes its structured log output - debug messages and the
+// match/dispatch fields RunSources emits - through logger instead of logrus's standard logger. Intended to
+// be daisychained with the New() constructor; as with WithDebugging, this is only a shallow copy.
+func (b *Bot) WithLogger(logger Logger) *Bot {
+	newB := *b
+	newB.logger = logger
+	return &newB
+}
+
+// log returns the bot's effective logger: the one set via WithLogger, or logrus's standard logger.
+func (b *Bot) log() Logger {
+	if b.logger != nil {
+		return b.logger
+	}
+	return log.StandardLogger()
+}
+
 // Run listens for incoming slack RTM events, matching them to an appropriate handler. It will terminate when the
 // provided channel is closed, or if it encounters an error during initial authentication.  Authentication is done
 // synchronously, and a non-nil error will be returned if an authentication error is encounters.  Once authentication
@@ -103,60 +136,35 @@ auth:
 		}
 	}
 
-	go func() {
-		for {
-			select {
-			case msg := <-b.RTM.IncomingEvents:
-				ctx := addBotToContext(context.Background(), b)
-				switch ev := msg.Data.(type) {
-				case *slack.MessageEvent:
-					ctx = addMessageToContext(ctx, ev)
-					var match RouteMatch
-					if matched, ctx := b.Match(ctx, &match); matched && match.Handler != nil {
-						match.Handler(ctx)
-					}
-
-				case *slack.RTMError:
-					log.WithError(ev).Error("[Slackbot] RTM Error.")
-
-				default:
-					// Ignore other events.
-				}
-			case <-quitCh:
-				b.debugf("[Slackbot] Quit event received.")
-				return
-			}
-		}
-	}()
+	b.AddSource(newRTMSource(b))
+	go b.RunSources(quitCh)
 
 	return nil
 }
 
 // Reply replies to a message event with a simple message.
-func (b *Bot) Reply(evt *slack.MessageEvent, msg string) {
+func (b *Bot) Reply(ctx context.Context, evt *slack.MessageEvent, msg string) {
 	if b.TypingDelayMultiplier > 0 {
-		b.TypeByMessage(evt, msg)
+		b.TypeByMessage(ctx, evt, msg)
 	}
-	b.RTM.SendMessage(b.RTM.NewOutgoingMessage(msg, evt.Channel))
+	b.send(ctx, Reply{Channel: evt.Channel, Text: msg})
 }
 
 // ReplyWithAttachments replys to a message event with a Slack Attachments message.
-func (b *Bot) ReplyWithAttachments(evt *slack.MessageEvent, msg string, attachments ...slack.Attachment) {
-	params := slack.PostMessageParameters{
-		AsUser:      true,
-		Attachments: attachments,
-	}
-
-	b.Client.PostMessage(evt.Msg.Channel, msg, params)
+func (b *Bot) ReplyWithAttachments(ctx context.Context, evt *slack.MessageEvent, msg string, attachments ...slack.Attachment) {
+	b.send(ctx, Reply{Channel: evt.Msg.Channel, Text: msg, Attachments: attachments})
 }
 
-// Type sends a typing event to indicate that the bot is "typing" or otherwise working.
-func (b *Bot) Type(evt *slack.MessageEvent) {
-	b.RTM.SendMessage(b.RTM.NewTypingMessage(evt.Channel))
+// Type sends a typing event to indicate that the bot is "typing" or otherwise working. Typing indicators are
+// an RTM-only feature; this is a no-op for bots running via RunSocketMode.
+func (b *Bot) Type(ctx context.Context, evt *slack.MessageEvent) {
+	if b.RTM != nil {
+		b.RTM.SendMessage(b.RTM.NewTypingMessage(evt.Channel))
+	}
 }
 
 // TypeByMessage sends a typing message and simulates delay (max 2000ms) based on message size.
-func (b *Bot) TypeByMessage(evt *slack.MessageEvent, msg interface{}) {
+func (b *Bot) TypeByMessage(ctx context.Context, evt *slack.MessageEvent, msg interface{}) {
 	msgLen := msgLen(msg)
 
 	sleepDuration := time.Duration(float64(time.Minute*time.Duration(msgLen)/30000) * (b.TypingDelayMultiplier))
@@ -164,10 +172,47 @@ func (b *Bot) TypeByMessage(evt *slack.MessageEvent, msg interface{}) {
 		sleepDuration = maxTypingSleep
 	}
 
-	b.Type(evt)
+	b.Type(ctx, evt)
 	time.Sleep(sleepDuration)
 }
 
+// ReplyInThread replies to a message event within its thread, using evt's ThreadTimestamp if it's already
+// part of one, or starting a new thread rooted at evt otherwise.
+func (b *Bot) ReplyInThread(ctx context.Context, evt *slack.MessageEvent, msg string) {
+	b.send(ctx, Reply{Channel: evt.Channel, Text: msg, ThreadTimestamp: threadTimestamp(evt)})
+}
+
+// ReplyBroadcast replies within evt's thread (see ReplyInThread) and also mirrors the reply to the channel,
+// the same way Slack's "Also send to #channel" checkbox does.
+func (b *Bot) ReplyBroadcast(ctx context.Context, evt *slack.MessageEvent, msg string) {
+	b.send(ctx, Reply{Channel: evt.Channel, Text: msg, ThreadTimestamp: threadTimestamp(evt), Broadcast: true})
+}
+
+// threadTimestamp returns the timestamp ReplyInThread/ReplyBroadcast should anchor to: the thread root if
+// evt already belongs to one, or evt's own timestamp to start a new thread.
+func threadTimestamp(evt *slack.MessageEvent) string {
+	if evt.ThreadTimestamp != "" {
+		return evt.ThreadTimestamp
+	}
+	return evt.Timestamp
+}
+
+// send routes a Reply through the MessageSource the context's message arrived on, so replies go back out
+// over the same connection a message came in on. If the context carries no source - a reply sent outside of
+// a handler, say - it falls back to the bot's own RTM connection for plain-text replies, or the Web API if
+// RTM isn't running or the reply is threaded or carries attachments.
+func (b *Bot) send(ctx context.Context, r Reply) error {
+	if src := SourceFromContext(ctx); src != nil {
+		return src.Send(r)
+	}
+	if b.RTM != nil && canUseRTMFastPath(r) {
+		b.RTM.SendMessage(b.RTM.NewOutgoingMessage(r.Text, r.Channel))
+		return nil
+	}
+	_, _, err := b.Client.PostMessage(r.Channel, msgOptionsForReply(r)...)
+	return err
+}
+
 // Fetch the botUserID.
 func (b *Bot) BotUserID() string {
 	return b.botUserID
@@ -180,7 +225,7 @@ func (b *Bot) setBotID(ID string) {
 
 func (b *Bot) debugf(format string, args ...interface{}) {
 	if b.debugging {
-		log.Debugf(format, args...)
+		b.log().Debugf(format, args...)
 	}
 }
 
@@ -194,3 +239,65 @@ func msgLen(msg interface{}) (msgLen int) {
 	}
 	return
 }
+
+// rtmSource adapts the RTM ingest loop to the MessageSource interface. Run registers one of these
+// automatically. A reply sent with no source in its context (e.g. from a handler invoked directly in a
+// test) does not go through an rtmSource: send uses the bot's RTM connection and Client itself.
+type rtmSource struct {
+	bot    *Bot
+	events chan Event
+	closed chan struct{}
+}
+
+func newRTMSource(b *Bot) *rtmSource {
+	src := &rtmSource{bot: b, events: make(chan Event), closed: make(chan struct{})}
+	go src.pump()
+	return src
+}
+
+func (s *rtmSource) pump() {
+	defer close(s.events)
+	for {
+		select {
+		case msg := <-s.bot.RTM.IncomingEvents:
+			switch ev := msg.Data.(type) {
+			case *slack.MessageEvent:
+				s.events <- Event{Message: ev, Source: s}
+
+			case *slack.RTMError:
+				log.WithError(ev).Error("[Slackbot] RTM Error.")
+
+			default:
+				// Ignore other events.
+			}
+		case <-s.closed:
+			return
+		}
+	}
+}
+
+func (s *rtmSource) Events() <-chan Event {
+	return s.events
+}
+
+func (s *rtmSource) Identity() string {
+	return "rtm"
+}
+
+func (s *rtmSource) Close() error {
+	select {
+	case <-s.closed:
+	default:
+		close(s.closed)
+	}
+	return nil
+}
+
+func (s *rtmSource) Send(r Reply) error {
+	if canUseRTMFastPath(r) {
+		s.bot.RTM.SendMessage(s.bot.RTM.NewOutgoingMessage(r.Text, r.Channel))
+		return nil
+	}
+	_, _, err := s.bot.Client.PostMessage(r.Channel, msgOptionsForReply(r)...)
+	return err
+}
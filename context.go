@@ -3,7 +3,7 @@ package slackbot
 import (
 	"context"
 
-	"github.com/nlopes/slack"
+	"github.com/slack-go/slack"
 )
 
 // key is unexported so other packages cannot access these keys directly or by mimicking their values.
@@ -13,6 +13,7 @@ type key int
 const (
 	bot_context_key key = iota
 	message_context_key
+	source_context_key
 )
 
 func BotFromContext(ctx context.Context) *Bot {
@@ -36,3 +37,17 @@ func MessageFromContext(ctx context.Context) *slack.MessageEvent {
 func addMessageToContext(ctx context.Context, msg *slack.MessageEvent) context.Context {
 	return context.WithValue(ctx, message_context_key, msg)
 }
+
+// SourceFromContext returns the MessageSource a message was received on, so a handler can reply on the
+// same connection it was addressed to. It returns nil for messages that didn't arrive via a MessageSource
+// (e.g. if a context was constructed directly rather than via RunSources).
+func SourceFromContext(ctx context.Context) MessageSource {
+	if result, ok := ctx.Value(source_context_key).(MessageSource); ok {
+		return result
+	}
+	return nil
+}
+
+func addSourceToContext(ctx context.Context, src MessageSource) context.Context {
+	return context.WithValue(ctx, source_context_key, src)
+}
@@ -0,0 +1,45 @@
+package slackbot
+
+import "github.com/kaedys/go-slackbot/interactive"
+
+// Interactive returns the Bot's interactive-components router, lazily constructing one the first time it's
+// needed. Register handlers on it with OnBlockAction, OnViewSubmission and OnSlashCommand (or the
+// equivalent methods on Bot below); serve it over HTTP, since it implements http.Handler, or feed it
+// payloads received over Socket Mode directly.
+func (b *Bot) Interactive() *interactive.Router {
+	if b.interactive == nil {
+		b.interactive = interactive.NewRouter(b.Client, b.signingSecret)
+	}
+	return b.interactive
+}
+
+// WithSigningSecret sets the signing secret used to verify interactive-component and slash-command requests
+// delivered to Bot.Interactive()'s ServeHTTP. Intended to be daisychained with a constructor, the same as
+// WithDebugging, but also safe to call after handlers have already been registered via OnBlockAction,
+// OnViewSubmission or OnSlashCommand - it updates the existing *interactive.Router's secret in place rather
+// than discarding it. Note that this is only a shallow copy, so it should be called before Run/RunSocketMode.
+func (b *Bot) WithSigningSecret(secret string) *Bot {
+	newB := *b
+	newB.signingSecret = secret
+	if newB.interactive != nil {
+		newB.interactive.SetSigningSecret(secret)
+	}
+	return &newB
+}
+
+// OnBlockAction registers a handler for block actions with the given action ID, e.g. a button's or select
+// menu's "action_id". See interactive.Router.
+func (b *Bot) OnBlockAction(actionID string, h interactive.BlockActionHandler) {
+	b.Interactive().OnBlockAction(actionID, h)
+}
+
+// OnViewSubmission registers a handler for view_submission callbacks whose modal has the given callback ID.
+// See interactive.Router.
+func (b *Bot) OnViewSubmission(callbackID string, h interactive.ViewSubmissionHandler) {
+	b.Interactive().OnViewSubmission(callbackID, h)
+}
+
+// OnSlashCommand registers a handler for a slash command, e.g. "/deploy". See interactive.Router.
+func (b *Bot) OnSlashCommand(command string, h interactive.SlashCommandHandler) {
+	b.Interactive().OnSlashCommand(command, h)
+}
@@ -0,0 +1,77 @@
+package interactive
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/slack-go/slack"
+)
+
+// ServeHTTP implements http.Handler. It verifies Slack's request signature, then dispatches
+// interaction_payload and slash_command requests to the handlers registered via OnBlockAction,
+// OnViewSubmission and OnSlashCommand. Wire it up with, e.g.:
+//
+//	http.Handle("/slack/interactive", router)
+//	http.Handle("/slack/commands", router)
+//
+// A Router with no signing secret refuses every request with 500, fail-closed, unless AllowUnsigned was
+// called to explicitly opt into serving unauthenticated requests.
+func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if r.signingSecret == "" && !r.allowUnsigned {
+		http.Error(w, "interactive router has no signing secret; call AllowUnsigned to serve HTTP without one", http.StatusInternalServerError)
+		return
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		http.Error(w, "failed to read request body", http.StatusBadRequest)
+		return
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	if r.signingSecret != "" {
+		verifier, err := slack.NewSecretsVerifier(req.Header, r.signingSecret)
+		if err != nil {
+			http.Error(w, "missing signature headers", http.StatusUnauthorized)
+			return
+		}
+		if _, err := verifier.Write(body); err != nil {
+			http.Error(w, "failed to verify signature", http.StatusInternalServerError)
+			return
+		}
+		if err := verifier.Ensure(); err != nil {
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+	}
+
+	if err := req.ParseForm(); err != nil {
+		http.Error(w, "failed to parse request", http.StatusBadRequest)
+		return
+	}
+
+	if payload := req.PostForm.Get("payload"); payload != "" {
+		var callback slack.InteractionCallback
+		if err := json.Unmarshal([]byte(payload), &callback); err != nil {
+			http.Error(w, "failed to parse interaction payload", http.StatusBadRequest)
+			return
+		}
+
+		resp := r.HandleInteraction(req.Context(), &callback)
+		if resp != nil {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(resp)
+		}
+		return
+	}
+
+	if cmd, err := slack.SlashCommandParse(req); err == nil && cmd.Command != "" {
+		r.HandleSlashCommand(req.Context(), cmd)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	http.Error(w, "unrecognized interactive payload", http.StatusBadRequest)
+}
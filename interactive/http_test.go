@@ -0,0 +1,53 @@
+package interactive
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+func TestServeHTTP_NoSigningSecretRefusesByDefault(t *testing.T) {
+	r := NewRouter(slack.New(""), "")
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("command=/deploy"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("expected %d when no signing secret is set and AllowUnsigned wasn't called, got %d", http.StatusInternalServerError, rec.Code)
+	}
+}
+
+func TestServeHTTP_AllowUnsignedOptsIn(t *testing.T) {
+	r := NewRouter(slack.New(""), "").AllowUnsigned()
+	r.OnSlashCommand("/deploy", func(_ context.Context, _ *slack.Client, _ slack.SlashCommand) {})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("command=/deploy&text=prod"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected %d once AllowUnsigned is set, got %d", http.StatusOK, rec.Code)
+	}
+}
+
+func TestServeHTTP_InvalidSignatureRejected(t *testing.T) {
+	r := NewRouter(slack.New(""), "mysecret")
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("command=/deploy"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Slack-Request-Timestamp", "1531420618")
+	req.Header.Set("X-Slack-Signature", "v0=not-a-real-signature")
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected %d for an invalid signature, got %d", http.StatusUnauthorized, rec.Code)
+	}
+}
@@ -0,0 +1,141 @@
+// Package interactive dispatches Slack's interactive components - block actions (buttons, select menus,
+// etc.), view (modal) submissions, and slash commands - to registered handlers, the same way the parent
+// slackbot package routes messages. A Router can be driven by its ServeHTTP method, wired up behind a
+// public endpoint, or fed already-parsed payloads directly (e.g. by a Socket Mode client).
+package interactive
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/slack-go/slack"
+)
+
+// BlockActionHandler handles a single block action - a button click, select menu choice, etc. - within an
+// interaction callback.
+type BlockActionHandler func(ctx context.Context, api *slack.Client, callback *slack.InteractionCallback, action *slack.BlockAction)
+
+// ViewSubmissionHandler handles a modal's view_submission callback. A non-nil return value is sent back to
+// Slack as the response_action body (e.g. to redisplay the view with validation errors); returning nil
+// simply closes the modal.
+type ViewSubmissionHandler func(ctx context.Context, api *slack.Client, callback *slack.InteractionCallback) *slack.ViewSubmissionResponse
+
+// SlashCommandHandler handles a slash command invocation.
+type SlashCommandHandler func(ctx context.Context, api *slack.Client, cmd slack.SlashCommand)
+
+// Router dispatches interactive-component and slash-command payloads to registered handlers.
+type Router struct {
+	api           *slack.Client
+	signingSecret string
+	allowUnsigned bool
+
+	blockActions    map[string]BlockActionHandler
+	viewSubmissions map[string]ViewSubmissionHandler
+	slashCommands   map[string]SlashCommandHandler
+}
+
+// NewRouter constructs a Router that uses api for follow-up calls (opening/updating modals, posting
+// ephemeral responses) and signingSecret to verify that requests delivered to ServeHTTP came from Slack.
+// signingSecret may be empty when the Router is only ever fed payloads directly, e.g. over Socket Mode,
+// which Slack signs at the connection level instead - but ServeHTTP will then refuse every request until
+// AllowUnsigned is called, since an HTTP-reachable router with no secret and no explicit opt-in would
+// otherwise accept forged interaction and slash-command payloads from anyone.
+func NewRouter(api *slack.Client, signingSecret string) *Router {
+	return &Router{
+		api:             api,
+		signingSecret:   signingSecret,
+		blockActions:    make(map[string]BlockActionHandler),
+		viewSubmissions: make(map[string]ViewSubmissionHandler),
+		slashCommands:   make(map[string]SlashCommandHandler),
+	}
+}
+
+// SetSigningSecret updates the Router's signing secret in place, preserving any handlers already registered
+// via OnBlockAction, OnViewSubmission and OnSlashCommand - unlike constructing a new Router with NewRouter,
+// which would discard them.
+func (r *Router) SetSigningSecret(secret string) {
+	r.signingSecret = secret
+}
+
+// AllowUnsigned opts the Router into serving ServeHTTP requests without verifying Slack's request
+// signature, for use when signingSecret is intentionally left empty (e.g. the Router sits behind its own
+// authenticating proxy). Without this, ServeHTTP refuses every request when no signing secret is set.
+func (r *Router) AllowUnsigned() *Router {
+	r.allowUnsigned = true
+	return r
+}
+
+// OnBlockAction registers a handler for block actions with the given action ID, e.g. a button's or select
+// menu's "action_id".
+func (r *Router) OnBlockAction(actionID string, h BlockActionHandler) {
+	r.blockActions[actionID] = h
+}
+
+// OnViewSubmission registers a handler for view_submission callbacks whose modal has the given callback ID.
+func (r *Router) OnViewSubmission(callbackID string, h ViewSubmissionHandler) {
+	r.viewSubmissions[callbackID] = h
+}
+
+// OnSlashCommand registers a handler for a slash command, e.g. "/deploy".
+func (r *Router) OnSlashCommand(command string, h SlashCommandHandler) {
+	r.slashCommands[command] = h
+}
+
+// HandleInteraction dispatches an already-parsed interaction callback - e.g. one delivered over Socket Mode
+// rather than ServeHTTP - to its registered handler, if any. A panicking handler is recovered and logged
+// rather than propagated, so it can't crash the goroutine dispatching it (socketSource.pump, for Socket
+// Mode, or the net/http server's own goroutine, for ServeHTTP).
+func (r *Router) HandleInteraction(ctx context.Context, callback *slack.InteractionCallback) *slack.ViewSubmissionResponse {
+	switch callback.Type {
+	case slack.InteractionTypeBlockActions:
+		for _, action := range callback.ActionCallback.BlockActions {
+			if h, ok := r.blockActions[action.ActionID]; ok {
+				r.safeCall(func() { h(ctx, r.api, callback, action) })
+			}
+		}
+
+	case slack.InteractionTypeViewSubmission:
+		if h, ok := r.viewSubmissions[callback.View.CallbackID]; ok {
+			var resp *slack.ViewSubmissionResponse
+			r.safeCall(func() { resp = h(ctx, r.api, callback) })
+			return resp
+		}
+	}
+	return nil
+}
+
+// HandleSlashCommand dispatches an already-parsed slash command - e.g. one delivered over Socket Mode
+// rather than ServeHTTP - to its registered handler, if any. As with HandleInteraction, a panicking handler
+// is recovered and logged rather than propagated.
+func (r *Router) HandleSlashCommand(ctx context.Context, cmd slack.SlashCommand) {
+	if h, ok := r.slashCommands[cmd.Command]; ok {
+		r.safeCall(func() { h(ctx, r.api, cmd) })
+	}
+}
+
+// safeCall invokes fn, recovering and logging any panic instead of letting it propagate, the same way
+// sources.go's dispatch protects message handlers in the parent slackbot package.
+func (r *Router) safeCall(fn func()) {
+	defer func() {
+		if p := recover(); p != nil {
+			log.WithField("handler_panic", p).Error("[Slackbot] Interactive handler panicked.")
+		}
+	}()
+	fn()
+}
+
+// OpenView opens a modal in response to a trigger ID, e.g. from within a SlashCommandHandler or
+// BlockActionHandler.
+func (r *Router) OpenView(triggerID string, view slack.ModalViewRequest) (*slack.ViewResponse, error) {
+	return r.api.OpenView(triggerID, view)
+}
+
+// UpdateView updates a previously opened modal, identified by viewID.
+func (r *Router) UpdateView(view slack.ModalViewRequest, hash, viewID string) (*slack.ViewResponse, error) {
+	return r.api.UpdateView(view, "", hash, viewID)
+}
+
+// PostEphemeral posts a message visible only to user within channel.
+func (r *Router) PostEphemeral(channel, user string, options ...slack.MsgOption) (string, error) {
+	return r.api.PostEphemeral(channel, user, options...)
+}
@@ -0,0 +1,118 @@
+package interactive
+
+import (
+	"context"
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+func TestHandleInteraction_DispatchesBlockAction(t *testing.T) {
+	r := NewRouter(slack.New(""), "")
+
+	var gotActionID string
+	r.OnBlockAction("approve_btn", func(_ context.Context, _ *slack.Client, _ *slack.InteractionCallback, action *slack.BlockAction) {
+		gotActionID = action.ActionID
+	})
+
+	callback := &slack.InteractionCallback{
+		Type: slack.InteractionTypeBlockActions,
+		ActionCallback: slack.ActionCallbacks{
+			BlockActions: []*slack.BlockAction{{ActionID: "approve_btn"}},
+		},
+	}
+
+	resp := r.HandleInteraction(context.Background(), callback)
+	if resp != nil {
+		t.Fatalf("expected a nil response for a block action, got %+v", resp)
+	}
+	if gotActionID != "approve_btn" {
+		t.Fatalf("expected the approve_btn handler to run, got action ID %q", gotActionID)
+	}
+}
+
+func TestHandleInteraction_UnregisteredBlockActionIsIgnored(t *testing.T) {
+	r := NewRouter(slack.New(""), "")
+
+	callback := &slack.InteractionCallback{
+		Type: slack.InteractionTypeBlockActions,
+		ActionCallback: slack.ActionCallbacks{
+			BlockActions: []*slack.BlockAction{{ActionID: "unregistered"}},
+		},
+	}
+
+	if resp := r.HandleInteraction(context.Background(), callback); resp != nil {
+		t.Fatalf("expected a nil response when no handler is registered, got %+v", resp)
+	}
+}
+
+func TestHandleInteraction_RecoversPanickingBlockActionHandler(t *testing.T) {
+	r := NewRouter(slack.New(""), "")
+
+	r.OnBlockAction("approve_btn", func(_ context.Context, _ *slack.Client, _ *slack.InteractionCallback, _ *slack.BlockAction) {
+		panic("boom")
+	})
+
+	callback := &slack.InteractionCallback{
+		Type: slack.InteractionTypeBlockActions,
+		ActionCallback: slack.ActionCallbacks{
+			BlockActions: []*slack.BlockAction{{ActionID: "approve_btn"}},
+		},
+	}
+
+	if resp := r.HandleInteraction(context.Background(), callback); resp != nil {
+		t.Fatalf("expected a nil response, got %+v", resp)
+	}
+}
+
+func TestHandleInteraction_RecoversPanickingViewSubmissionHandler(t *testing.T) {
+	r := NewRouter(slack.New(""), "")
+
+	r.OnViewSubmission("deploy_modal", func(_ context.Context, _ *slack.Client, _ *slack.InteractionCallback) *slack.ViewSubmissionResponse {
+		panic("boom")
+	})
+
+	callback := &slack.InteractionCallback{
+		Type: slack.InteractionTypeViewSubmission,
+		View: slack.View{CallbackID: "deploy_modal"},
+	}
+
+	if resp := r.HandleInteraction(context.Background(), callback); resp != nil {
+		t.Fatalf("expected a nil response from a recovered panic, got %+v", resp)
+	}
+}
+
+func TestHandleSlashCommand_RecoversPanickingHandler(t *testing.T) {
+	r := NewRouter(slack.New(""), "")
+
+	ran := false
+	r.OnSlashCommand("/deploy", func(_ context.Context, _ *slack.Client, _ slack.SlashCommand) {
+		ran = true
+		panic("boom")
+	})
+
+	r.HandleSlashCommand(context.Background(), slack.SlashCommand{Command: "/deploy"})
+
+	if !ran {
+		t.Fatal("expected the handler to have run before panicking")
+	}
+}
+
+func TestHandleInteraction_ViewSubmissionReturnsHandlerResponse(t *testing.T) {
+	r := NewRouter(slack.New(""), "")
+
+	want := &slack.ViewSubmissionResponse{ResponseAction: "clear"}
+	r.OnViewSubmission("deploy_modal", func(_ context.Context, _ *slack.Client, _ *slack.InteractionCallback) *slack.ViewSubmissionResponse {
+		return want
+	})
+
+	callback := &slack.InteractionCallback{
+		Type: slack.InteractionTypeViewSubmission,
+		View: slack.View{CallbackID: "deploy_modal"},
+	}
+
+	got := r.HandleInteraction(context.Background(), callback)
+	if got != want {
+		t.Fatalf("expected the view submission handler's response to be returned, got %+v", got)
+	}
+}
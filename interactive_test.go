@@ -0,0 +1,31 @@
+package slackbot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+func TestWithSigningSecret_PreservesAlreadyRegisteredHandlers(t *testing.T) {
+	b := New("")
+
+	var ran bool
+	b.OnBlockAction("approve_btn", func(_ context.Context, _ *slack.Client, _ *slack.InteractionCallback, _ *slack.BlockAction) {
+		ran = true
+	})
+
+	b = b.WithSigningSecret("mysecret")
+
+	callback := &slack.InteractionCallback{
+		Type: slack.InteractionTypeBlockActions,
+		ActionCallback: slack.ActionCallbacks{
+			BlockActions: []*slack.BlockAction{{ActionID: "approve_btn"}},
+		},
+	}
+	b.Interactive().HandleInteraction(context.Background(), callback)
+
+	if !ran {
+		t.Fatal("expected the block action handler registered before WithSigningSecret to still be reachable")
+	}
+}
@@ -0,0 +1,109 @@
+package slackbot
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// loggerFromContext returns the Logger of the Bot carried in ctx, falling back to logrus's standard logger
+// when ctx carries no Bot (e.g. a handler invoked directly in a test). This keeps LoggingMiddleware and
+// RecoveryMiddleware writing through the same Logger WithLogger configures for RunSources's own
+// match/dispatch logging, rather than always going straight to the global logrus logger.
+func loggerFromContext(ctx context.Context) Logger {
+	if b := BotFromContext(ctx); b != nil {
+		return b.log()
+	}
+	return log.StandardLogger()
+}
+
+// LoggingMiddleware logs the user, channel and handling latency of every matched message at info level,
+// using structured logrus fields, through the dispatching Bot's Logger.
+func LoggingMiddleware(next Handler) Handler {
+	return func(ctx context.Context) {
+		start := time.Now()
+		next(ctx)
+
+		fields := log.Fields{"latency_ms": time.Since(start).Milliseconds()}
+		if msg := MessageFromContext(ctx); msg != nil {
+			fields["user"] = msg.User
+			fields["channel"] = msg.Channel
+		}
+		loggerFromContext(ctx).WithFields(fields).Info("[Slackbot] Handled message.")
+	}
+}
+
+// RecoveryMiddleware recovers a panicking handler, logging the panic - through the dispatching Bot's Logger
+// - instead of crashing the process.
+func RecoveryMiddleware(next Handler) Handler {
+	return func(ctx context.Context) {
+		defer func() {
+			if p := recover(); p != nil {
+				loggerFromContext(ctx).WithField("handler_panic", p).Error("[Slackbot] Handler panicked.")
+			}
+		}()
+		next(ctx)
+	}
+}
+
+// RateLimitMiddleware returns a Middleware that allows at most rate handler dispatches per per, per
+// user/channel pair, refilling as a token bucket so bursts up to rate are allowed. Messages over the limit
+// are dropped without invoking the handler.
+func RateLimitMiddleware(rate int, per time.Duration) Middleware {
+	limiter := newRateLimiter(rate, per)
+	return func(next Handler) Handler {
+		return func(ctx context.Context) {
+			msg := MessageFromContext(ctx)
+			if msg == nil || limiter.Allow(msg.User+":"+msg.Channel) {
+				next(ctx)
+			}
+		}
+	}
+}
+
+type rateLimiter struct {
+	mu      sync.Mutex
+	rate    float64
+	perTick time.Duration
+	buckets map[string]*tokenBucket
+}
+
+type tokenBucket struct {
+	tokens     float64
+	lastRefill time.Time
+}
+
+func newRateLimiter(rate int, per time.Duration) *rateLimiter {
+	return &rateLimiter{
+		rate:    float64(rate),
+		perTick: per,
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// Allow reports whether the bucket for key has a token available, consuming one if so.
+func (l *rateLimiter) Allow(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: l.rate, lastRefill: now}
+		l.buckets[key] = b
+	} else {
+		b.tokens += now.Sub(b.lastRefill).Seconds() * (l.rate / l.perTick.Seconds())
+		if b.tokens > l.rate {
+			b.tokens = l.rate
+		}
+		b.lastRefill = now
+	}
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
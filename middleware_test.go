@@ -0,0 +1,74 @@
+package slackbot
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestChain_AppliesInRegistrationOrderOutermostFirst(t *testing.T) {
+	var order []string
+
+	record := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context) {
+				order = append(order, "before:"+name)
+				next(ctx)
+				order = append(order, "after:"+name)
+			}
+		}
+	}
+
+	handler := chain(func(ctx context.Context) {
+		order = append(order, "handler")
+	}, []Middleware{record("outer"), record("inner")})
+
+	handler(context.Background())
+
+	want := []string{"before:outer", "before:inner", "handler", "after:inner", "after:outer"}
+	if len(order) != len(want) {
+		t.Fatalf("got %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("got %v, want %v", order, want)
+		}
+	}
+}
+
+func TestChain_NoMiddlewareReturnsHandlerUnchanged(t *testing.T) {
+	called := false
+	handler := chain(func(ctx context.Context) { called = true }, nil)
+	handler(context.Background())
+	if !called {
+		t.Fatal("expected the handler to run when no middleware is chained")
+	}
+}
+
+func TestRateLimiter_AllowsBurstUpToRateThenBlocks(t *testing.T) {
+	limiter := newRateLimiter(2, time.Second)
+
+	if !limiter.Allow("k") {
+		t.Fatal("expected the first call to be allowed")
+	}
+	if !limiter.Allow("k") {
+		t.Fatal("expected the second call (within the burst) to be allowed")
+	}
+	if limiter.Allow("k") {
+		t.Fatal("expected the third call to be blocked once the bucket is empty")
+	}
+}
+
+func TestRateLimiter_TracksKeysIndependently(t *testing.T) {
+	limiter := newRateLimiter(1, time.Second)
+
+	if !limiter.Allow("a") {
+		t.Fatal("expected the first call for key a to be allowed")
+	}
+	if !limiter.Allow("b") {
+		t.Fatal("expected the first call for a different key b to be allowed independently of a")
+	}
+	if limiter.Allow("a") {
+		t.Fatal("expected a second call for key a to be blocked")
+	}
+}
@@ -0,0 +1,113 @@
+// Package plugin loads out-of-tree command handlers into a slackbot.Router without recompiling the bot
+// binary. Each plugin is a Go plugin (see the standard library's plugin package) built with
+// `go build -buildmode=plugin` that exports a Register(slackbot.Router) error symbol; the loader opens
+// every .so file in a directory and calls it.
+//
+// Subprocess-based plugins are not yet implemented - Register's signature assumes an in-process Router, and
+// bridging that across a process boundary needs its own RPC protocol - so only the Go plugin path works
+// today.
+package plugin
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	goplugin "plugin"
+
+	"github.com/kaedys/go-slackbot"
+)
+
+// RegisterFunc is the symbol each plugin .so must export, named "Register".
+type RegisterFunc func(r slackbot.Router) error
+
+// Plugin tracks a loaded .so so it can later be reloaded or unloaded.
+type Plugin struct {
+	Path string
+
+	plug *goplugin.Plugin
+}
+
+// Loader opens Go plugins from a directory and registers their routes with a Router.
+type Loader struct {
+	Dir    string
+	Router slackbot.Router
+
+	plugins map[string]*Plugin
+}
+
+// NewLoader constructs a Loader that will open plugins from dir and register them with router.
+func NewLoader(dir string, router slackbot.Router) *Loader {
+	return &Loader{
+		Dir:     dir,
+		Router:  router,
+		plugins: make(map[string]*Plugin),
+	}
+}
+
+// LoadAll walks the loader's directory, opening every .so file it finds and calling its Register symbol.
+func (l *Loader) LoadAll() error {
+	entries, err := os.ReadDir(l.Dir)
+	if err != nil {
+		return fmt.Errorf("reading plugin directory %s: %w", l.Dir, err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".so" {
+			continue
+		}
+		if err := l.Load(filepath.Join(l.Dir, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Load opens a single plugin file and calls its Register symbol to add its routes to the Loader's Router.
+func (l *Loader) Load(path string) error {
+	plug, err := goplugin.Open(path)
+	if err != nil {
+		return fmt.Errorf("opening plugin %s: %w", path, err)
+	}
+
+	sym, err := plug.Lookup("Register")
+	if err != nil {
+		return fmt.Errorf("plugin %s does not export Register: %w", path, err)
+	}
+
+	register, ok := sym.(func(slackbot.Router) error)
+	if !ok {
+		return fmt.Errorf("plugin %s's Register has the wrong signature, want func(slackbot.Router) error", path)
+	}
+
+	if err := register(l.Router); err != nil {
+		return fmt.Errorf("registering plugin %s: %w", path, err)
+	}
+
+	l.plugins[path] = &Plugin{Path: path, plug: plug}
+	return nil
+}
+
+// Reload re-opens and re-registers a previously loaded plugin. Go's plugin package returns the already
+// loaded plugin when the same path is opened again, so Reload calls that plugin's Register a second time;
+// a rebuilt .so at the same path is not picked up. Go also cannot unload code from a running process, so
+// the plugin's previously registered routes remain live alongside the newly registered ones; restart the
+// bot to fully replace a plugin's routes.
+func (l *Loader) Reload(path string) error {
+	delete(l.plugins, path)
+	return l.Load(path)
+}
+
+// Unload stops tracking a plugin so it is no longer reported by Loaded. As with Reload, its routes remain
+// registered until the bot restarts, since Go cannot unload plugin code from a running process.
+func (l *Loader) Unload(path string) {
+	delete(l.plugins, path)
+}
+
+// Loaded returns the paths of every currently tracked plugin.
+func (l *Loader) Loaded() []string {
+	paths := make([]string, 0, len(l.plugins))
+	for path := range l.plugins {
+		paths = append(paths, path)
+	}
+	return paths
+}
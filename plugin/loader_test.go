@@ -0,0 +1,38 @@
+package plugin
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadAll_MissingDirectoryReturnsError(t *testing.T) {
+	l := NewLoader(filepath.Join(t.TempDir(), "does-not-exist"), nil)
+	if err := l.LoadAll(); err == nil {
+		t.Fatal("expected an error reading a missing plugin directory")
+	}
+}
+
+func TestLoadAll_EmptyDirectoryIsANoOp(t *testing.T) {
+	l := NewLoader(t.TempDir(), nil)
+	if err := l.LoadAll(); err != nil {
+		t.Fatalf("expected no error for an empty directory, got %v", err)
+	}
+	if loaded := l.Loaded(); len(loaded) != 0 {
+		t.Fatalf("expected no plugins loaded, got %v", loaded)
+	}
+}
+
+func TestUnload_RemovesFromLoaded(t *testing.T) {
+	l := NewLoader(t.TempDir(), nil)
+	l.plugins["fake.so"] = &Plugin{Path: "fake.so"}
+
+	if loaded := l.Loaded(); len(loaded) != 1 {
+		t.Fatalf("expected one tracked plugin before Unload, got %v", loaded)
+	}
+
+	l.Unload("fake.so")
+
+	if loaded := l.Loaded(); len(loaded) != 0 {
+		t.Fatalf("expected no tracked plugins after Unload, got %v", loaded)
+	}
+}
@@ -3,6 +3,8 @@ package slackbot
 import (
 	"context"
 	"regexp"
+
+	"github.com/slack-go/slack"
 )
 
 type Route struct {
@@ -13,6 +15,16 @@ type Route struct {
 	preprocessor Preprocessor
 	botUserID    string
 	talkToSelf   bool // if set, the bot can reply to its own messages
+	matchEdits   bool // if set, Hear matchers on this route also match the edited text of message_changed events
+	middlewares  []Middleware
+	name         string // optional, set via Name; used only to identify the route in logging
+}
+
+// Name sets a label for the route, included as the "route" field in RunSources's structured dispatch
+// logging. Routes are unnamed by default.
+func (r *Route) Name(name string) *Route {
+	r.name = name
+	return r
 }
 
 func (r *Route) setBotID(botID string) {
@@ -51,16 +63,29 @@ func (r *Route) Match(ctx context.Context, match *RouteMatch) (bool, context.Con
 		}
 	}
 
-	// if this route contains a subrouter, invoke the subrouter match
+	// if this route contains a subrouter, invoke the subrouter match, then apply this route's own
+	// middleware around whatever handler the subrouter matched - otherwise Use on a route with a
+	// subrouter would be silently ignored.
 	if r.subrouter != nil {
-		return r.subrouter.Match(ctx, match)
+		matched, ctx := r.subrouter.Match(ctx, match)
+		if matched {
+			match.Handler = chain(match.Handler, r.middlewares)
+		}
+		return matched, ctx
 	}
 
 	match.Route = r
-	match.Handler = r.handler
+	match.Handler = chain(r.handler, r.middlewares)
 	return true, ctx
 }
 
+// Use registers middleware to wrap this route's handler, in registration order - the first middleware
+// added runs outermost, closest to the router's own middleware (see Router.Use).
+func (r *Route) Use(mw ...Middleware) *Route {
+	r.middlewares = append(r.middlewares, mw...)
+	return r
+}
+
 func (r *Route) TalkToSelf() *Route {
 	r.talkToSelf = true
 	return r
@@ -82,6 +107,25 @@ func (r *Route) Messages(types ...MessageType) *Route {
 	return r
 }
 
+// Thread adds a matcher that only matches messages which are part of a thread, using the ThreadTimestamp
+// of the incoming slack.MessageEvent.
+func (r *Route) Thread() *Route {
+	r.AddMatcher(&ThreadMatcher{})
+	return r
+}
+
+// HearEdits makes this route's Hear matchers also match the edited text of message_changed events (Slack
+// reports an edit as a MessageEvent whose SubMessage carries the new text), not just freshly posted ones.
+func (r *Route) HearEdits() *Route {
+	r.matchEdits = true
+	for _, m := range r.matchers {
+		if rm, ok := m.(*RegexpMatcher); ok {
+			rm.matchEdits = true
+		}
+	}
+	return r
+}
+
 // Handler sets a handler for the route.
 func (r *Route) Handler(handler Handler) error {
 	if r.err != nil {
@@ -124,14 +168,19 @@ func (r *Route) Err() error {
 // ============================================================================
 
 type RegexpMatcher struct {
-	regex     *regexp.Regexp
-	botUserID string
+	regex      *regexp.Regexp
+	botUserID  string
+	matchEdits bool // if set, match against msg.SubMessage.Text for message_changed events
 }
 
 func (rm *RegexpMatcher) Match(ctx context.Context) (bool, context.Context) {
 	msg := MessageFromContext(ctx)
+	text := msg.Text
+	if rm.matchEdits && msg.SubType == slack.MsgSubTypeMessageChanged && msg.SubMessage != nil {
+		text = msg.SubMessage.Text
+	}
 	// A message may be received via a direct mention. For simplicity sake, strip out any potention direct mentions first
-	text := StripDirectMention(msg.Text)
+	text = StripDirectMention(text)
 	// Now match the stripped text against the regular expression
 	matched := rm.regex.MatchString(text)
 	return matched, ctx
@@ -148,7 +197,7 @@ func (r *Route) addRegexpMatcher(regex string) {
 		r.err = err
 	}
 
-	r.AddMatcher(&RegexpMatcher{regex: re})
+	r.AddMatcher(&RegexpMatcher{regex: re, matchEdits: r.matchEdits})
 }
 
 // ============================================================================
@@ -185,3 +234,20 @@ func (tm *TypesMatcher) SetBotID(botID string) {
 func (r *Route) addTypesMatcher(types ...MessageType) {
 	r.AddMatcher(&TypesMatcher{types: types, botUserID: r.botUserID})
 }
+
+// ============================================================================
+// Thread Matcher
+// ============================================================================
+
+type ThreadMatcher struct {
+	botUserID string
+}
+
+func (tm *ThreadMatcher) Match(ctx context.Context) (bool, context.Context) {
+	msg := MessageFromContext(ctx)
+	return msg.ThreadTimestamp != "", ctx
+}
+
+func (tm *ThreadMatcher) SetBotID(botID string) {
+	tm.botUserID = botID
+}
@@ -0,0 +1,61 @@
+package slackbot
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+func TestRegexpMatcher_MatchesPlainText(t *testing.T) {
+	m := &RegexpMatcher{regex: regexp.MustCompile("(?i)hello")}
+	ctx := addMessageToContext(context.Background(), &slack.MessageEvent{Msg: slack.Msg{Text: "well, hello there"}})
+
+	matched, _ := m.Match(ctx)
+	if !matched {
+		t.Fatal("expected the regexp matcher to match the message text")
+	}
+}
+
+func TestRegexpMatcher_IgnoresEditsByDefault(t *testing.T) {
+	m := &RegexpMatcher{regex: regexp.MustCompile("(?i)hello")}
+	msg := &slack.MessageEvent{
+		Msg:        slack.Msg{SubType: slack.MsgSubTypeMessageChanged, Text: ""},
+		SubMessage: &slack.Msg{Text: "hello there"},
+	}
+	ctx := addMessageToContext(context.Background(), msg)
+
+	matched, _ := m.Match(ctx)
+	if matched {
+		t.Fatal("expected a matcher without matchEdits to ignore a message_changed event's edited text")
+	}
+}
+
+func TestRegexpMatcher_MatchEditsChecksSubMessage(t *testing.T) {
+	m := &RegexpMatcher{regex: regexp.MustCompile("(?i)hello"), matchEdits: true}
+	msg := &slack.MessageEvent{
+		Msg:        slack.Msg{SubType: slack.MsgSubTypeMessageChanged, Text: ""},
+		SubMessage: &slack.Msg{Text: "hello there"},
+	}
+	ctx := addMessageToContext(context.Background(), msg)
+
+	matched, _ := m.Match(ctx)
+	if !matched {
+		t.Fatal("expected matchEdits to match against the SubMessage text of a message_changed event")
+	}
+}
+
+func TestThreadMatcher_MatchesOnlyThreadedMessages(t *testing.T) {
+	m := &ThreadMatcher{}
+
+	threaded := addMessageToContext(context.Background(), &slack.MessageEvent{Msg: slack.Msg{ThreadTimestamp: "123.456"}})
+	if matched, _ := m.Match(threaded); !matched {
+		t.Fatal("expected a message with a ThreadTimestamp to match")
+	}
+
+	unthreaded := addMessageToContext(context.Background(), &slack.MessageEvent{Msg: slack.Msg{}})
+	if matched, _ := m.Match(unthreaded); matched {
+		t.Fatal("expected a message with no ThreadTimestamp not to match")
+	}
+}
@@ -0,0 +1,53 @@
+package slackbot
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRoute_UseWrapsSubrouterMatch(t *testing.T) {
+	router := &SimpleRouter{}
+	var ran []string
+
+	r := router.NoTalkToSelf()
+	// Route.Match gates on r.handler being non-nil before it ever looks at the subrouter; set a
+	// placeholder here so the match reaches the subrouter branch below, whose result is what's actually
+	// dispatched.
+	if err := r.Handler(func(context.Context) {}); err != nil {
+		t.Fatalf("unexpected error setting placeholder handler: %v", err)
+	}
+	sub := r.Subrouter()
+	err := sub.Handler(func(ctx context.Context) { ran = append(ran, "handler") })
+	if err != nil {
+		t.Fatalf("unexpected error registering subrouter handler: %v", err)
+	}
+
+	r.Use(func(next Handler) Handler {
+		return func(ctx context.Context) {
+			ran = append(ran, "before")
+			next(ctx)
+			ran = append(ran, "after")
+		}
+	})
+
+	var match RouteMatch
+	matched, ctx := router.Match(context.Background(), &match)
+	if !matched {
+		t.Fatal("expected the route to match")
+	}
+	if match.Handler == nil {
+		t.Fatal("expected a non-nil handler")
+	}
+
+	match.Handler(ctx)
+
+	want := []string{"before", "handler", "after"}
+	if len(ran) != len(want) {
+		t.Fatalf("got %v, want %v", ran, want)
+	}
+	for i := range want {
+		if ran[i] != want[i] {
+			t.Fatalf("got %v, want %v - route.Use did not wrap the subrouter's matched handler", ran, want)
+		}
+	}
+}
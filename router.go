@@ -9,11 +9,14 @@ type Router interface {
 	SetBotID(botID string)
 	Hear(regex string) *Route
 	Messages(types ...MessageType) *Route
+	Thread() *Route
+	HearEdits() *Route
 	AddMatcher(m Matcher) *Route
 	TalkToSelf() *Route
 	NoTalkToSelf() *Route
 	AlwaysTalkToSelf() Router
 	NeverTalkToSelf() Router
+	Use(mw ...Middleware) Router
 	Handler(handler Handler) error
 	MessageHandler(handler MessageHandler) error
 	Err() error
@@ -28,6 +31,9 @@ type SimpleRouter struct {
 	err error
 	// if set, all new routes will be set to allow self-talking
 	talkToSelf bool
+	// middleware wrapped around the handler of any route matched by this router, applied outside any
+	// middleware the matched route or a subrouter added themselves
+	middlewares []Middleware
 }
 
 // Match matches registered routes against the request.
@@ -38,6 +44,7 @@ func (r *SimpleRouter) Match(ctx context.Context, match *RouteMatch) (bool, cont
 
 	for _, route := range r.routes {
 		if matched, ctx := route.Match(ctx, match); matched {
+			match.Handler = chain(match.Handler, r.middlewares)
 			return true, ctx
 		}
 	}
@@ -71,6 +78,14 @@ func (r *SimpleRouter) Messages(types ...MessageType) *Route {
 	return r.newRoute(r.talkToSelf).Messages(types...)
 }
 
+func (r *SimpleRouter) Thread() *Route {
+	return r.newRoute(r.talkToSelf).Thread()
+}
+
+func (r *SimpleRouter) HearEdits() *Route {
+	return r.newRoute(r.talkToSelf).HearEdits()
+}
+
 func (r *SimpleRouter) AddMatcher(m Matcher) *Route {
 	return r.newRoute(r.talkToSelf).AddMatcher(m)
 }
@@ -92,6 +107,14 @@ func (r *SimpleRouter) NeverTalkToSelf() Router {
 	return r
 }
 
+// Use registers middleware to wrap the handler of any route this router matches, in registration order -
+// the first middleware added runs outermost. It applies regardless of whether the route was registered
+// before or after Use was called.
+func (r *SimpleRouter) Use(mw ...Middleware) Router {
+	r.middlewares = append(r.middlewares, mw...)
+	return r
+}
+
 func (r *SimpleRouter) TalkToSelf() *Route {
 	return r.newRoute(true)
 }
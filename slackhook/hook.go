@@ -0,0 +1,93 @@
+// Package slackhook provides a logrus.Hook that ships error-level (and above) log entries to a Slack
+// channel as attachments colored by severity, so whoever's watching the channel sees the same failures
+// operators would otherwise only find by tailing logs.
+package slackhook
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/slack-go/slack"
+)
+
+// entryBuffer bounds how many log entries Fire will queue for posting before it starts dropping them
+// rather than blocking its caller.
+const entryBuffer = 64
+
+// Hook posts error-level and above logrus entries to a Slack channel as a colored attachment. Install it
+// with logrus.AddHook. Fire only enqueues the entry; a background goroutine started by New does the actual
+// posting, so a handler that panics repeatedly can't stall the dispatch goroutine logging its panics
+// waiting on Slack's API. Entries queued beyond entryBuffer are dropped rather than blocking Fire.
+type Hook struct {
+	Client  *slack.Client
+	Channel string
+
+	entries chan *logrus.Entry
+}
+
+// New constructs a Hook that posts entries to channel using client, and starts the background goroutine
+// that sends them.
+func New(client *slack.Client, channel string) *Hook {
+	h := &Hook{Client: client, Channel: channel, entries: make(chan *logrus.Entry, entryBuffer)}
+	go h.run()
+	return h
+}
+
+// run drains queued entries and posts each to Slack, one at a time, for the lifetime of the process.
+func (h *Hook) run() {
+	for entry := range h.entries {
+		if err := h.post(entry); err != nil {
+			logrus.WithError(err).Error("[slackhook] Failed to post log entry to Slack.")
+		}
+	}
+}
+
+// Levels reports that this hook fires for panic, fatal and error level entries only.
+func (h *Hook) Levels() []logrus.Level {
+	return []logrus.Level{logrus.PanicLevel, logrus.FatalLevel, logrus.ErrorLevel}
+}
+
+// Fire enqueues entry to be posted to the configured Slack channel by the background goroutine started in
+// New. It never blocks: if the queue is full, the entry is dropped.
+func (h *Hook) Fire(entry *logrus.Entry) error {
+	select {
+	case h.entries <- entry.Dup():
+	default:
+		// Queue is full - drop rather than block whatever's logging.
+	}
+	return nil
+}
+
+// post sends a single entry to Slack as an attachment colored by severity.
+func (h *Hook) post(entry *logrus.Entry) error {
+	attachment := slack.Attachment{
+		Color:    color(entry.Level),
+		Fallback: entry.Message,
+		Text:     entry.Message,
+		Fields:   fieldsFor(entry),
+	}
+
+	_, _, err := h.Client.PostMessage(h.Channel, slack.MsgOptionAttachments(attachment), slack.MsgOptionAsUser(true))
+	return err
+}
+
+// color maps a logrus level to the Slack attachment color that best conveys its severity.
+func color(level logrus.Level) string {
+	switch level {
+	case logrus.PanicLevel, logrus.FatalLevel:
+		return "danger"
+	case logrus.ErrorLevel:
+		return "warning"
+	default:
+		return "good"
+	}
+}
+
+// fieldsFor renders an entry's structured fields as Slack attachment fields.
+func fieldsFor(entry *logrus.Entry) []slack.AttachmentField {
+	fields := make([]slack.AttachmentField, 0, len(entry.Data))
+	for k, v := range entry.Data {
+		fields = append(fields, slack.AttachmentField{Title: k, Value: fmt.Sprintf("%v", v), Short: true})
+	}
+	return fields
+}
@@ -0,0 +1,54 @@
+package slackhook
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestLevels_OnlyErrorAndAbove(t *testing.T) {
+	h := &Hook{}
+	levels := h.Levels()
+
+	want := map[logrus.Level]bool{logrus.PanicLevel: true, logrus.FatalLevel: true, logrus.ErrorLevel: true}
+	if len(levels) != len(want) {
+		t.Fatalf("got %v, want exactly panic/fatal/error", levels)
+	}
+	for _, l := range levels {
+		if !want[l] {
+			t.Fatalf("unexpected level %v in %v", l, levels)
+		}
+	}
+}
+
+func TestColor_MapsSeverityToAttachmentColor(t *testing.T) {
+	cases := []struct {
+		level logrus.Level
+		want  string
+	}{
+		{logrus.PanicLevel, "danger"},
+		{logrus.FatalLevel, "danger"},
+		{logrus.ErrorLevel, "warning"},
+		{logrus.InfoLevel, "good"},
+	}
+	for _, c := range cases {
+		if got := color(c.level); got != c.want {
+			t.Errorf("color(%v) = %q, want %q", c.level, got, c.want)
+		}
+	}
+}
+
+func TestFire_DropsEntriesWhenQueueIsFull(t *testing.T) {
+	// Don't start the background sender; Fire should still never block even once the buffer fills.
+	h := &Hook{entries: make(chan *logrus.Entry, 2)}
+
+	for i := 0; i < entryBuffer+5; i++ {
+		if err := h.Fire(logrus.WithField("i", i)); err != nil {
+			t.Fatalf("Fire returned an error: %v", err)
+		}
+	}
+
+	if len(h.entries) != 2 {
+		t.Fatalf("expected the queue to stay at its capacity of 2, got %d", len(h.entries))
+	}
+}
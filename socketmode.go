@@ -0,0 +1,187 @@
+package slackbot
+
+import (
+	"context"
+
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+	"github.com/slack-go/slack/socketmode"
+	log "github.com/sirupsen/logrus"
+)
+
+// NewWithAppToken constructs a new Bot that authorizes against the Slack service using a bot token (xoxb-...)
+// for API calls and an app-level token (xapp-...) to open Socket Mode connections. Use the returned Bot with
+// RunSocketMode rather than Run.
+func NewWithAppToken(botToken, appToken string) *Bot {
+	return &Bot{
+		Client:                slack.New(botToken, slack.OptionAppLevelToken(appToken)),
+		TypingDelayMultiplier: 0,
+	}
+}
+
+// RunSocketMode opens a Socket Mode connection and registers it as a MessageSource, translating incoming
+// Slack Events API events into the existing *slack.MessageEvent shape and matching them to an appropriate
+// handler via the same router used by Run. Unlike Run, it requires no public endpoint and keeps working on
+// apps for which Slack has deprecated RTM. It blocks until the provided channel is closed.
+func (b *Bot) RunSocketMode(quitCh <-chan struct{}) error {
+	b.socketClient = socketmode.New(b.Client, socketmode.OptionDebug(b.debugging))
+	go b.socketClient.Run()
+
+	b.AddSource(newSocketSource(b, b.socketClient))
+	return b.RunSources(quitCh)
+}
+
+// socketSource adapts a Socket Mode connection to the MessageSource interface; RunSocketMode registers one
+// automatically.
+type socketSource struct {
+	bot    *Bot
+	client *socketmode.Client
+	events chan Event
+	closed chan struct{}
+}
+
+func newSocketSource(b *Bot, client *socketmode.Client) *socketSource {
+	src := &socketSource{bot: b, client: client, events: make(chan Event), closed: make(chan struct{})}
+	go src.pump()
+	return src
+}
+
+func (s *socketSource) pump() {
+	defer close(s.events)
+	for {
+		select {
+		case evt := <-s.client.Events:
+			switch evt.Type {
+			case socketmode.EventTypeConnected:
+				s.bot.debugf("[Slackbot] Connected via Socket Mode.")
+
+			case socketmode.EventTypeConnectionError:
+				log.Error("[Slackbot] Socket Mode connection error.")
+
+			case socketmode.EventTypeEventsAPI:
+				outer, ok := evt.Data.(slackevents.EventsAPIEvent)
+				if !ok {
+					break
+				}
+				if evt.Request != nil {
+					s.client.Ack(*evt.Request)
+				}
+				if msg := messageFromEventsAPI(outer); msg != nil {
+					s.events <- Event{Message: msg, Source: s}
+				}
+
+			case socketmode.EventTypeInteractive:
+				callback, ok := evt.Data.(slack.InteractionCallback)
+				if !ok {
+					break
+				}
+				resp := s.bot.Interactive().HandleInteraction(context.Background(), &callback)
+				if evt.Request != nil {
+					if resp != nil {
+						s.client.Ack(*evt.Request, resp)
+					} else {
+						s.client.Ack(*evt.Request)
+					}
+				}
+
+			case socketmode.EventTypeSlashCommand:
+				cmd, ok := evt.Data.(slack.SlashCommand)
+				if !ok {
+					break
+				}
+				s.bot.Interactive().HandleSlashCommand(context.Background(), cmd)
+				if evt.Request != nil {
+					s.client.Ack(*evt.Request)
+				}
+
+			default:
+				// Ignore other event types.
+			}
+		case <-s.closed:
+			return
+		}
+	}
+}
+
+func (s *socketSource) Events() <-chan Event {
+	return s.events
+}
+
+func (s *socketSource) Identity() string {
+	return "socketmode"
+}
+
+func (s *socketSource) Close() error {
+	select {
+	case <-s.closed:
+	default:
+		close(s.closed)
+	}
+	return nil
+}
+
+func (s *socketSource) Send(r Reply) error {
+	_, _, err := s.bot.Client.PostMessage(r.Channel, msgOptionsForReply(r)...)
+	return err
+}
+
+// messageFromEventsAPI translates the inner event of a Slack Events API callback into a *slack.MessageEvent.
+func messageFromEventsAPI(outer slackevents.EventsAPIEvent) *slack.MessageEvent {
+	if outer.Type != slackevents.CallbackEvent {
+		return nil
+	}
+	return messageEventFromInnerEvent(outer.InnerEvent.Data)
+}
+
+// messageEventFromInnerEvent adapts the Events API inner events go-slackbot knows how to route into a
+// *slack.MessageEvent, so Hear/Messages/MessageHandler routes work the same regardless of transport.
+func messageEventFromInnerEvent(data interface{}) *slack.MessageEvent {
+	switch ev := data.(type) {
+	case *slackevents.MessageEvent:
+		msgEvt := &slack.MessageEvent{Msg: slack.Msg{
+			Type:            "message",
+			SubType:         ev.SubType,
+			Channel:         ev.Channel,
+			User:            ev.User,
+			Text:            ev.Text,
+			Timestamp:       ev.TimeStamp,
+			ThreadTimestamp: ev.ThreadTimeStamp,
+			BotID:           ev.BotID,
+		}}
+		// For message_changed events, Slack carries the edited text in the nested "message" object rather
+		// than the top-level Text field (which is empty); populate SubMessage so HearEdits() routes work the
+		// same way they do over RTM, where SubMessage comes for free via JSON unmarshaling.
+		if ev.SubType == "message_changed" && ev.Message != nil {
+			msgEvt.SubMessage = &slack.Msg{
+				Type:            "message",
+				User:            ev.Message.User,
+				Text:            ev.Message.Text,
+				Timestamp:       ev.Message.TimeStamp,
+				ThreadTimestamp: ev.Message.ThreadTimeStamp,
+				BotID:           ev.Message.BotID,
+			}
+		}
+		return msgEvt
+
+	case *slackevents.AppMentionEvent:
+		return &slack.MessageEvent{Msg: slack.Msg{
+			Type:            "message",
+			Channel:         ev.Channel,
+			User:            ev.User,
+			Text:            ev.Text,
+			Timestamp:       ev.TimeStamp,
+			ThreadTimestamp: ev.ThreadTimeStamp,
+			BotID:           ev.BotID,
+		}}
+
+	case *slackevents.ReactionAddedEvent:
+		return &slack.MessageEvent{Msg: slack.Msg{
+			Type:    "reaction_added",
+			Channel: ev.Item.Channel,
+			User:    ev.User,
+		}}
+
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,54 @@
+package slackbot
+
+import (
+	"testing"
+
+	"github.com/slack-go/slack/slackevents"
+)
+
+func TestMessageEventFromInnerEvent_MessageChangedPopulatesSubMessage(t *testing.T) {
+	inner := &slackevents.MessageEvent{
+		SubType:   "message_changed",
+		Channel:   "C123",
+		TimeStamp: "1531420618.000100",
+		Message: &slackevents.MessageEvent{
+			User:      "U123",
+			Text:      "edited text",
+			TimeStamp: "1531420600.000100",
+		},
+	}
+
+	msg := messageEventFromInnerEvent(inner)
+	if msg == nil {
+		t.Fatal("expected a non-nil message")
+	}
+	if msg.SubMessage == nil {
+		t.Fatal("expected SubMessage to be populated for a message_changed event")
+	}
+	if msg.SubMessage.Text != "edited text" {
+		t.Errorf("expected SubMessage.Text %q, got %q", "edited text", msg.SubMessage.Text)
+	}
+	if msg.SubMessage.User != "U123" {
+		t.Errorf("expected SubMessage.User %q, got %q", "U123", msg.SubMessage.User)
+	}
+}
+
+func TestMessageEventFromInnerEvent_PlainMessageHasNoSubMessage(t *testing.T) {
+	inner := &slackevents.MessageEvent{
+		Channel:   "C123",
+		User:      "U123",
+		Text:      "hello",
+		TimeStamp: "1531420618.000100",
+	}
+
+	msg := messageEventFromInnerEvent(inner)
+	if msg == nil {
+		t.Fatal("expected a non-nil message")
+	}
+	if msg.SubMessage != nil {
+		t.Errorf("expected no SubMessage for a plain message, got %+v", msg.SubMessage)
+	}
+	if msg.Text != "hello" {
+		t.Errorf("expected Text %q, got %q", "hello", msg.Text)
+	}
+}
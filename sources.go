@@ -0,0 +1,144 @@
+package slackbot
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/slack-go/slack"
+)
+
+// Event is a message observed by a MessageSource, tagged with the source it arrived on so a handler can
+// reply on the same connection the message came in on.
+type Event struct {
+	Message *slack.MessageEvent
+	Source  MessageSource
+}
+
+// Reply is an outgoing message addressed to a channel, independent of which MessageSource delivers it.
+type Reply struct {
+	Channel         string
+	Text            string
+	Attachments     []slack.Attachment
+	ThreadTimestamp string
+	Broadcast       bool
+}
+
+// MessageSource abstracts an ingest/egress connection to a chat backend: Slack RTM, Slack Socket Mode, a
+// fake in-memory source for tests, or even a non-Slack backend. A Bot multiplexes events from every
+// registered source into its router, and uses the originating source to send replies, so a single Bot can
+// serve multiple workspaces or transports at once.
+type MessageSource interface {
+	// Events returns the channel the source publishes incoming messages on. It is closed when the source
+	// shuts down.
+	Events() <-chan Event
+	// Send delivers a Reply on this source's connection.
+	Send(Reply) error
+	// Identity names the source, e.g. a workspace or connection label, for logging and diagnostics.
+	Identity() string
+	// Close shuts down the source's connection. Events will close shortly after Close returns.
+	Close() error
+}
+
+// canUseRTMFastPath reports whether r is simple enough to send via RTM's lightweight SendMessage - plain
+// text with no attachments and no thread placement - or whether it needs the richer Web API call
+// msgOptionsForReply builds for instead.
+func canUseRTMFastPath(r Reply) bool {
+	return len(r.Attachments) == 0 && r.ThreadTimestamp == ""
+}
+
+// msgOptionsForReply builds the MsgOptions needed to send a Reply via the Web API, shared by the
+// MessageSource implementations that don't have an RTM fast path available.
+func msgOptionsForReply(r Reply) []slack.MsgOption {
+	opts := []slack.MsgOption{slack.MsgOptionText(r.Text, false), slack.MsgOptionAsUser(true)}
+	if len(r.Attachments) > 0 {
+		opts = append(opts, slack.MsgOptionAttachments(r.Attachments...))
+	}
+	if r.ThreadTimestamp != "" {
+		opts = append(opts, slack.MsgOptionTS(r.ThreadTimestamp))
+		if r.Broadcast {
+			opts = append(opts, slack.MsgOptionBroadcast())
+		}
+	}
+	return opts
+}
+
+// dispatch invokes the matched route's handler, if any, and logs the outcome via the bot's Logger with
+// structured fields - user, channel, route, matched, latency_ms, and handler_panic if the handler panicked -
+// giving operators a single place to plug in observability, via WithLogger, for every message the bot sees.
+func (b *Bot) dispatch(ctx context.Context, msg *slack.MessageEvent, match *RouteMatch, matched bool) {
+	fields := log.Fields{"matched": matched}
+	if msg != nil {
+		fields["user"] = msg.User
+		fields["channel"] = msg.Channel
+	}
+	if matched && match.Route != nil {
+		fields["route"] = match.Route.name
+	}
+
+	if !matched || match.Handler == nil {
+		b.log().WithFields(fields).Debug("[Slackbot] No route matched message.")
+		return
+	}
+
+	start := time.Now()
+	defer func() {
+		fields["latency_ms"] = time.Since(start).Milliseconds()
+		if p := recover(); p != nil {
+			fields["handler_panic"] = p
+			b.log().WithFields(fields).Error("[Slackbot] Handler panicked.")
+			return
+		}
+		b.log().WithFields(fields).Info("[Slackbot] Dispatched message to handler.")
+	}()
+	match.Handler(ctx)
+}
+
+// AddSource registers an additional MessageSource to be multiplexed into the router by RunSources. Run and
+// RunSocketMode call this for you; use it directly to run a Bot against multiple sources at once.
+func (b *Bot) AddSource(src MessageSource) {
+	b.sources = append(b.sources, src)
+}
+
+// RunSources multiplexes events from every MessageSource registered via AddSource into the router,
+// matching them to a handler exactly as Run does for RTM events. It terminates when quitCh is closed, at
+// which point every registered source is closed.
+func (b *Bot) RunSources(quitCh <-chan struct{}) error {
+	fanIn := make(chan Event)
+
+	var wg sync.WaitGroup
+	for _, src := range b.sources {
+		wg.Add(1)
+		go func(src MessageSource) {
+			defer wg.Done()
+			for evt := range src.Events() {
+				fanIn <- evt
+			}
+		}(src)
+	}
+	go func() {
+		wg.Wait()
+		close(fanIn)
+	}()
+
+	for {
+		select {
+		case evt, ok := <-fanIn:
+			if !ok {
+				return nil
+			}
+			ctx := addSourceToContext(context.Background(), evt.Source)
+			ctx = addMessageToContext(addBotToContext(ctx, b), evt.Message)
+			var match RouteMatch
+			matched, ctx := b.Match(ctx, &match)
+			b.dispatch(ctx, evt.Message, &match, matched)
+
+		case <-quitCh:
+			for _, src := range b.sources {
+				src.Close()
+			}
+			return nil
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package slackbot
+
+import (
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+func TestMsgOptionsForReply_PlainText(t *testing.T) {
+	opts := msgOptionsForReply(Reply{Channel: "C1", Text: "hi"})
+	if len(opts) != 2 {
+		t.Fatalf("expected text + as-user options for a plain reply, got %d", len(opts))
+	}
+}
+
+func TestMsgOptionsForReply_ThreadedWithBroadcast(t *testing.T) {
+	opts := msgOptionsForReply(Reply{Channel: "C1", Text: "hi", ThreadTimestamp: "123.456", Broadcast: true})
+	if len(opts) != 4 {
+		t.Fatalf("expected text + as-user + ts + broadcast options, got %d", len(opts))
+	}
+}
+
+func TestMsgOptionsForReply_WithAttachmentsNoThread(t *testing.T) {
+	opts := msgOptionsForReply(Reply{Channel: "C1", Text: "hi", Attachments: []slack.Attachment{{Text: "a"}}})
+	if len(opts) != 3 {
+		t.Fatalf("expected text + as-user + attachments options, got %d", len(opts))
+	}
+}
+
+func TestCanUseRTMFastPath(t *testing.T) {
+	cases := []struct {
+		name string
+		r    Reply
+		want bool
+	}{
+		{"plain text", Reply{Text: "hi"}, true},
+		{"with attachments", Reply{Text: "hi", Attachments: []slack.Attachment{{Text: "a"}}}, false},
+		{"threaded", Reply{Text: "hi", ThreadTimestamp: "123.456"}, false},
+		{"threaded with attachments", Reply{Text: "hi", ThreadTimestamp: "123.456", Attachments: []slack.Attachment{{Text: "a"}}}, false},
+	}
+	for _, c := range cases {
+		if got := canUseRTMFastPath(c.r); got != c.want {
+			t.Errorf("%s: canUseRTMFastPath() = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
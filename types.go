@@ -3,7 +3,7 @@ package slackbot
 import (
 	"context"
 
-	"github.com/nlopes/slack"
+	"github.com/slack-go/slack"
 )
 
 type MessageType int
@@ -17,6 +17,20 @@ type Handler func(context.Context)
 type MessageHandler func(ctx context.Context, bot *Bot, msg *slack.MessageEvent)
 type Preprocessor func(context.Context) context.Context
 
+// Middleware wraps a Handler to add cross-cutting behavior - logging, metrics, panic recovery, rate
+// limiting, authz checks, tracing spans - around its dispatch. Unlike a Preprocessor, a Middleware can run
+// code after the handler returns, or skip calling it entirely to short-circuit dispatch.
+type Middleware func(Handler) Handler
+
+// chain wraps h with mws in registration order, so the first middleware added is the outermost - it runs
+// first and returns last.
+func chain(h Handler, mws []Middleware) Handler {
+	for i := len(mws) - 1; i >= 0; i-- {
+		h = mws[i](h)
+	}
+	return h
+}
+
 // Matcher type for matching message routes
 type Matcher interface {
 	Match(context.Context) (bool, context.Context)